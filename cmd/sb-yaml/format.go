@@ -0,0 +1,138 @@
+package main
+
+import (
+    "bytes"
+    "fmt"
+    "io/fs"
+    "os"
+    "path/filepath"
+    "strings"
+
+    "github.com/spf13/cobra"
+    "gopkg.in/yaml.v3"
+
+    "yaml-formatter/rules"
+    "yaml-formatter/sorter"
+)
+
+// 문서마다 apiVersion/kind 로 내장 k8s rule 을 고르는 rule 이름
+const k8sAuto = "k8s-auto"
+
+type ruleFunc func(doc *yaml.Node) (*sorter.Rule, error)
+
+func newFormatCmd() *cobra.Command {
+    return &cobra.Command{
+        Use:   "format <rule|k8s-auto> <files or dirs...>",
+        Short: "파일을 rule 순서대로 정렬해서 덮어쓴다",
+        Long: `rule 은 내장 rule 이름(docker-compose-spec, github-actions, k8s-deployment ...)이나
+rule 파일 경로. k8s-auto 는 문서마다 apiVersion/kind 를 보고 내장 k8s rule 을 고른다.
+디렉토리를 주면 그 아래 .yaml/.yml 파일을 전부 정렬한다.`,
+        Args: cobra.MinimumNArgs(2),
+        RunE: func(cmd *cobra.Command, args []string) error {
+            ruleFor, err := resolveRule(args[0])
+            if err != nil {
+                return err
+            }
+
+            files, err := expandFiles(args[1:])
+            if err != nil {
+                return err
+            }
+
+            for _, file := range files {
+                changed, err := formatFile(file, ruleFor)
+                if err != nil {
+                    return fmt.Errorf("%s: %w", file, err)
+                }
+                if changed {
+                    fmt.Fprintln(cmd.OutOrStdout(), file)
+                }
+            }
+            return nil
+        },
+    }
+}
+
+// rule 이름을 문서별 rule 을 돌려주는 함수로 바꾼다. 같은 rule 은 한 번만 읽는다.
+func resolveRule(name string) (ruleFunc, error) {
+    cache := make(map[string]*sorter.Rule)
+    load := func(file string) (*sorter.Rule, error) {
+        if rule, ok := cache[file]; ok {
+            return rule, nil
+        }
+        rule, err := sorter.LoadRuleFS(rules.FS, file)
+        if err != nil {
+            return nil, err
+        }
+        cache[file] = rule
+        return rule, nil
+    }
+
+    if name == k8sAuto {
+        return func(doc *yaml.Node) (*sorter.Rule, error) {
+            return load(sorter.K8sRuleName(doc))
+        }, nil
+    }
+
+    // 내장 rule 이 없으면 rule 파일 경로로 본다
+    rule, err := load(name + ".rule.yaml")
+    if err != nil {
+        rule, err = sorter.LoadRule(name)
+        if err != nil {
+            return nil, fmt.Errorf("unknown rule %q: %w", name, err)
+        }
+    }
+    return func(*yaml.Node) (*sorter.Rule, error) {
+        return rule, nil
+    }, nil
+}
+
+func expandFiles(args []string) ([]string, error) {
+    var files []string
+    for _, arg := range args {
+        info, err := os.Stat(arg)
+        if err != nil {
+            return nil, err
+        }
+        if !info.IsDir() {
+            files = append(files, arg)
+            continue
+        }
+
+        err = filepath.WalkDir(arg, func(path string, d fs.DirEntry, err error) error {
+            if err != nil {
+                return err
+            }
+            ext := strings.ToLower(filepath.Ext(path))
+            if !d.IsDir() && (ext == ".yaml" || ext == ".yml") {
+                files = append(files, path)
+            }
+            return nil
+        })
+        if err != nil {
+            return nil, err
+        }
+    }
+    return files, nil
+}
+
+// 정렬 결과가 원본과 다를 때만 원래 권한 그대로 덮어쓴다
+func formatFile(path string, ruleFor ruleFunc) (bool, error) {
+    info, err := os.Stat(path)
+    if err != nil {
+        return false, err
+    }
+    data, err := os.ReadFile(path)
+    if err != nil {
+        return false, err
+    }
+
+    out, err := sorter.Format(data, ruleFor)
+    if err != nil {
+        return false, err
+    }
+    if bytes.Equal(out, data) {
+        return false, nil
+    }
+    return true, os.WriteFile(path, out, info.Mode().Perm())
+}
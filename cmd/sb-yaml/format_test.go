@@ -0,0 +1,134 @@
+package main
+
+import (
+    "bytes"
+    "os"
+    "path/filepath"
+    "testing"
+)
+
+func runFormat(t *testing.T, args ...string) (string, error) {
+    t.Helper()
+    cmd := newFormatCmd()
+    var out bytes.Buffer
+    cmd.SetOut(&out)
+    cmd.SetErr(&out)
+    cmd.SetArgs(args)
+    err := cmd.Execute()
+    return out.String(), err
+}
+
+func TestFormatK8sAuto(t *testing.T) {
+    dir := t.TempDir()
+    manifests := filepath.Join(dir, "manifests")
+    if err := os.MkdirAll(manifests, 0755); err != nil {
+        t.Fatal(err)
+    }
+
+    app := filepath.Join(manifests, "app.yaml")
+    input := `kind: Deployment
+apiVersion: apps/v1
+spec:
+  template:
+    spec:
+      containers:
+        - image: nginx
+          name: web
+metadata:
+  name: web
+---
+spec:
+  ports:
+    - port: 80
+      name: http
+metadata:
+  name: web
+kind: Service
+apiVersion: v1
+`
+    want := `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+spec:
+  template:
+    spec:
+      containers:
+        - name: web
+          image: nginx
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: web
+spec:
+  ports:
+    - name: http
+      port: 80
+`
+    if err := os.WriteFile(app, []byte(input), 0600); err != nil {
+        t.Fatal(err)
+    }
+    sorted := filepath.Join(manifests, "sorted.yml")
+    if err := os.WriteFile(sorted, []byte("apiVersion: v1\nkind: ConfigMap\n"), 0644); err != nil {
+        t.Fatal(err)
+    }
+
+    out, err := runFormat(t, k8sAuto, manifests)
+    if err != nil {
+        t.Fatalf("format: %v", err)
+    }
+    // 바뀐 파일만 출력한다
+    if out != app+"\n" {
+        t.Errorf("output = %q, want only %s", out, app)
+    }
+
+    got, err := os.ReadFile(app)
+    if err != nil {
+        t.Fatal(err)
+    }
+    if string(got) != want {
+        t.Errorf("got:\n%s\nwant:\n%s", got, want)
+    }
+
+    info, err := os.Stat(app)
+    if err != nil {
+        t.Fatal(err)
+    }
+    if info.Mode().Perm() != 0600 {
+        t.Errorf("mode = %v, want 0600", info.Mode().Perm())
+    }
+}
+
+func TestFormatRuleFile(t *testing.T) {
+    dir := t.TempDir()
+    rule := filepath.Join(dir, "my.rule.yaml")
+    if err := os.WriteFile(rule, []byte("b:\na:\n"), 0644); err != nil {
+        t.Fatal(err)
+    }
+    file := filepath.Join(dir, "x.yaml")
+    if err := os.WriteFile(file, []byte("a: 1\nb: 2\n"), 0644); err != nil {
+        t.Fatal(err)
+    }
+
+    if _, err := runFormat(t, rule, file); err != nil {
+        t.Fatalf("format: %v", err)
+    }
+    got, err := os.ReadFile(file)
+    if err != nil {
+        t.Fatal(err)
+    }
+    if string(got) != "b: 2\na: 1\n" {
+        t.Errorf("got:\n%s", got)
+    }
+}
+
+func TestFormatUnknownRule(t *testing.T) {
+    file := filepath.Join(t.TempDir(), "x.yaml")
+    if err := os.WriteFile(file, []byte("a: 1\n"), 0644); err != nil {
+        t.Fatal(err)
+    }
+    if _, err := runFormat(t, "no-such-rule", file); err == nil {
+        t.Error("expected error for unknown rule")
+    }
+}
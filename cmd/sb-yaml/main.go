@@ -0,0 +1,22 @@
+package main
+
+import (
+    "fmt"
+    "os"
+
+    "github.com/spf13/cobra"
+)
+
+func main() {
+    var rootCmd = &cobra.Command{
+        Use:          "sb-yaml",
+        Short:        "rule 순서대로 YAML 키를 정렬",
+        SilenceUsage: true,
+    }
+
+    rootCmd.AddCommand(newFormatCmd())
+    if err := rootCmd.Execute(); err != nil {
+        fmt.Println(err)
+        os.Exit(1)
+    }
+}
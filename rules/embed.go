@@ -0,0 +1,8 @@
+package rules
+
+import "embed"
+
+// 바이너리에 같이 들어가는 기본 rule 파일들
+//
+//go:embed *.rule.yaml
+var FS embed.FS
@@ -0,0 +1,23 @@
+package rules
+
+import (
+    "io/fs"
+    "testing"
+
+    "yaml-formatter/sorter"
+)
+
+func TestEmbeddedRulesParse(t *testing.T) {
+    names, err := fs.Glob(FS, "*.rule.yaml")
+    if err != nil {
+        t.Fatal(err)
+    }
+    if len(names) == 0 {
+        t.Fatal("no embedded rules")
+    }
+    for _, name := range names {
+        if _, err := sorter.LoadRuleFS(FS, name); err != nil {
+            t.Errorf("%s: %v", name, err)
+        }
+    }
+}
@@ -0,0 +1,42 @@
+package sorter
+
+import "testing"
+
+func TestFormatAnsiblePlaybook(t *testing.T) {
+    rule := loadRule(t, "../rules/ansible.rule.yaml")
+    input := `- tasks:
+    - notify: restart nginx
+      when: ansible_os_family == "Debian"
+      name: Install nginx
+      register: result
+      apt:
+        name: nginx
+      become: true
+    - name: Start nginx
+      service:
+        name: nginx
+        state: started
+  become: yes
+  hosts: web
+  name: Web servers
+`
+    want := `- name: Web servers
+  hosts: web
+  become: yes
+  tasks:
+    - name: Install nginx
+      apt:
+        name: nginx
+      become: true
+      when: ansible_os_family == "Debian"
+      register: result
+      notify: restart nginx
+    - name: Start nginx
+      service:
+        name: nginx
+        state: started
+`
+    if got := formatWith(t, rule, input); got != want {
+        t.Errorf("got:\n%s\nwant:\n%s", got, want)
+    }
+}
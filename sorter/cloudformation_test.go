@@ -0,0 +1,52 @@
+package sorter
+
+import "testing"
+
+func TestFormatCloudFormationKeepsTags(t *testing.T) {
+    rule := loadRule(t, "../rules/cloudformation.rule.yaml")
+    input := `Outputs:
+  BucketArn:
+    Value: !GetAtt Bucket.Arn
+    Description: Bucket ARN
+Resources:
+  Bucket:
+    Properties:
+      BucketName: !Sub "${AWS::StackName}-data"
+      Tags:
+        - Key: Stack
+          Value: !Ref AWS::StackName
+    DeletionPolicy: Retain
+    Type: AWS::S3::Bucket
+  Policy:
+    Properties:
+      Bucket: !Ref Bucket
+    Type: AWS::S3::BucketPolicy
+Conditions:
+  IsProd: !Equals [!Ref Env, prod]
+AWSTemplateFormatVersion: "2010-09-09"
+`
+    want := `AWSTemplateFormatVersion: "2010-09-09"
+Conditions:
+  IsProd: !Equals [!Ref Env, prod]
+Resources:
+  Bucket:
+    Type: AWS::S3::Bucket
+    DeletionPolicy: Retain
+    Properties:
+      BucketName: !Sub "${AWS::StackName}-data"
+      Tags:
+        - Key: Stack
+          Value: !Ref AWS::StackName
+  Policy:
+    Type: AWS::S3::BucketPolicy
+    Properties:
+      Bucket: !Ref Bucket
+Outputs:
+  BucketArn:
+    Description: Bucket ARN
+    Value: !GetAtt Bucket.Arn
+`
+    if got := formatWith(t, rule, input); got != want {
+        t.Errorf("got:\n%s\nwant:\n%s", got, want)
+    }
+}
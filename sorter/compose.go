@@ -0,0 +1,46 @@
+package sorter
+
+import (
+    "path/filepath"
+
+    "gopkg.in/yaml.v3"
+)
+
+// compose spec 에만 있는 최상위 키
+var composeSpecKeys = []string{"name", "include"}
+
+// compose spec 에만 있는 서비스 키
+var composeSpecServiceKeys = []string{"develop", "pull_policy"}
+
+// compose spec 형식이면 docker-compose-spec.rule.yaml, version 만 있는 예전 형식이면 docker-compose.rule.yaml
+func ComposeRulePath(dir string, doc *yaml.Node) string {
+    if isComposeSpec(doc) {
+        return filepath.Join(dir, "docker-compose-spec.rule.yaml")
+    }
+    return filepath.Join(dir, "docker-compose.rule.yaml")
+}
+
+func isComposeSpec(doc *yaml.Node) bool {
+    root := doc
+    if doc != nil && doc.Kind == yaml.DocumentNode && len(doc.Content) > 0 {
+        root = doc.Content[0]
+    }
+
+    for _, key := range composeSpecKeys {
+        if MappingValue(root, key) != nil {
+            return true
+        }
+    }
+    if services := MappingValue(root, "services"); services != nil && services.Kind == yaml.MappingNode {
+        for i := 1; i < len(services.Content); i += 2 {
+            for _, key := range composeSpecServiceKeys {
+                if MappingValue(services.Content[i], key) != nil {
+                    return true
+                }
+            }
+        }
+    }
+
+    // spec 필드가 없으면 version 유무로 판단
+    return MappingValue(root, "version") == nil
+}
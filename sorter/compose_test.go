@@ -0,0 +1,110 @@
+package sorter
+
+import (
+    "path/filepath"
+    "testing"
+
+    "gopkg.in/yaml.v3"
+)
+
+func TestComposeRulePath(t *testing.T) {
+    tests := []struct {
+        name  string
+        input string
+        want  string
+    }{
+        {"version only", "version: \"3.8\"\nservices: {}\n", "docker-compose.rule.yaml"},
+        {"no version", "services: {}\n", "docker-compose-spec.rule.yaml"},
+        {"version with name", "version: \"3.8\"\nname: app\nservices: {}\n", "docker-compose-spec.rule.yaml"},
+        {"version with spec service key", "version: \"3.8\"\nservices:\n  web:\n    pull_policy: always\n", "docker-compose-spec.rule.yaml"},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            var doc yaml.Node
+            if err := yaml.Unmarshal([]byte(tt.input), &doc); err != nil {
+                t.Fatal(err)
+            }
+            if got := ComposeRulePath("rules", &doc); got != filepath.Join("rules", tt.want) {
+                t.Errorf("ComposeRulePath = %s, want %s", got, tt.want)
+            }
+        })
+    }
+}
+
+func TestFormatComposeTopLevel(t *testing.T) {
+    input := `x-common: &common
+  restart: always
+networks:
+  default:
+volumes:
+  data:
+services:
+  db:
+    volumes:
+      - data:/var/lib/postgresql/data
+    image: postgres:15
+    <<: *common
+name: app
+`
+    want := `name: app
+x-common: &common
+  restart: always
+services:
+  db:
+    <<: *common
+    image: postgres:15
+    volumes:
+      - data:/var/lib/postgresql/data
+volumes:
+  data:
+networks:
+  default:
+`
+    out, err := Format([]byte(input), func(doc *yaml.Node) (*Rule, error) {
+        return LoadRule(ComposeRulePath("../rules", doc))
+    })
+    if err != nil {
+        t.Fatalf("Format: %v", err)
+    }
+    if string(out) != want {
+        t.Errorf("got:\n%s\nwant:\n%s", out, want)
+    }
+}
+
+// 앵커를 정의한 x-common 이 rule 에 없어서 뒤로 가면 "<<: *common" 이 깨진다
+func TestFormatComposeKeepsAnchorsBeforeAliases(t *testing.T) {
+    input := `x-common: &common
+  restart: always
+x-env: &env
+  TZ: UTC
+services:
+  web:
+    environment: *env
+    <<: *common
+    image: nginx
+`
+    want := `x-common: &common
+  restart: always
+x-env: &env
+  TZ: UTC
+services:
+  web:
+    <<: *common
+    image: nginx
+    environment: *env
+`
+    out, err := Format([]byte(input), func(doc *yaml.Node) (*Rule, error) {
+        return LoadRule(ComposeRulePath("../rules", doc))
+    })
+    if err != nil {
+        t.Fatalf("Format: %v", err)
+    }
+    if string(out) != want {
+        t.Errorf("got:\n%s\nwant:\n%s", out, want)
+    }
+
+    var reparsed interface{}
+    if err := yaml.Unmarshal(out, &reparsed); err != nil {
+        t.Errorf("formatted output does not parse: %v", err)
+    }
+}
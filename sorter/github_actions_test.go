@@ -0,0 +1,48 @@
+package sorter
+
+import "testing"
+
+func TestFormatGitHubActionsSteps(t *testing.T) {
+    rule := loadRule(t, "../rules/github-actions.rule.yaml")
+    input := `jobs:
+  test:
+    steps:
+      - uses: actions/checkout@v4
+      - with:
+          go-version: "1.22"
+        uses: actions/setup-go@v5
+        name: Set up Go
+      - run: |
+          go vet ./...
+          go test ./...
+        name: Test
+    runs-on: ubuntu-latest
+    needs: [lint, build]
+on:
+  push:
+    branches: [main]
+name: CI
+`
+    want := `name: CI
+on:
+  push:
+    branches: [main]
+jobs:
+  test:
+    needs: [lint, build]
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@v4
+      - name: Set up Go
+        uses: actions/setup-go@v5
+        with:
+          go-version: "1.22"
+      - name: Test
+        run: |
+          go vet ./...
+          go test ./...
+`
+    if got := formatWith(t, rule, input); got != want {
+        t.Errorf("got:\n%s\nwant:\n%s", got, want)
+    }
+}
@@ -0,0 +1,47 @@
+package sorter
+
+import (
+    "path/filepath"
+    "strings"
+
+    "gopkg.in/yaml.v3"
+)
+
+// "<api group>/<kind>" 별 rule 파일. core group 은 빈 문자열
+var k8sKindRules = map[string]string{
+    "apps/Deployment":           "k8s-deployment.rule.yaml",
+    "/Service":                  "k8s-service.rule.yaml",
+    "networking.k8s.io/Ingress": "k8s-ingress.rule.yaml",
+}
+
+// 문서의 apiVersion/kind 에 맞는 rule 파일 경로. 없는 kind 나 CRD 는 k8s.rule.yaml
+func K8sRulePath(dir string, doc *yaml.Node) string {
+    return filepath.Join(dir, K8sRuleName(doc))
+}
+
+// 문서의 apiVersion/kind 에 맞는 rule 파일 이름
+func K8sRuleName(doc *yaml.Node) string {
+    root := doc
+    if doc != nil && doc.Kind == yaml.DocumentNode && len(doc.Content) > 0 {
+        root = doc.Content[0]
+    }
+
+    var apiVersion, kind string
+    if v := MappingValue(root, "apiVersion"); v != nil {
+        apiVersion = v.Value
+    }
+    if v := MappingValue(root, "kind"); v != nil {
+        kind = v.Value
+    }
+
+    // apps/v1 -> apps, v1 -> ""
+    group := ""
+    if i := strings.LastIndex(apiVersion, "/"); i >= 0 {
+        group = apiVersion[:i]
+    }
+
+    if name, ok := k8sKindRules[group+"/"+kind]; ok {
+        return name
+    }
+    return "k8s.rule.yaml"
+}
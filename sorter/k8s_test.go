@@ -0,0 +1,131 @@
+package sorter
+
+import (
+    "path/filepath"
+    "testing"
+
+    "gopkg.in/yaml.v3"
+)
+
+func TestK8sRulePath(t *testing.T) {
+    tests := []struct {
+        name  string
+        input string
+        want  string
+    }{
+        {"deployment", "apiVersion: apps/v1\nkind: Deployment\n", "k8s-deployment.rule.yaml"},
+        {"core service", "apiVersion: v1\nkind: Service\n", "k8s-service.rule.yaml"},
+        {"ingress", "apiVersion: networking.k8s.io/v1\nkind: Ingress\n", "k8s-ingress.rule.yaml"},
+        {"crd with same kind", "apiVersion: serving.knative.dev/v1\nkind: Service\n", "k8s.rule.yaml"},
+        {"unknown kind", "apiVersion: v1\nkind: ConfigMap\n", "k8s.rule.yaml"},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            var doc yaml.Node
+            if err := yaml.Unmarshal([]byte(tt.input), &doc); err != nil {
+                t.Fatal(err)
+            }
+            if got := K8sRulePath("rules", &doc); got != filepath.Join("rules", tt.want) {
+                t.Errorf("K8sRulePath = %s, want %s", got, tt.want)
+            }
+        })
+    }
+}
+
+func TestFormatK8sPerKind(t *testing.T) {
+    input := `kind: Deployment
+apiVersion: apps/v1
+spec:
+  template:
+    spec:
+      initContainers:
+        - command: ["sh", "-c", "migrate"]
+          image: migrate
+          name: migrate
+      containers:
+        - image: nginx
+          ports:
+            - containerPort: 80
+          name: web
+        - env:
+            - value: "1"
+              name: B
+            - name: A
+              value: "2"
+          name: sidecar
+          image: busybox
+metadata:
+  name: web
+---
+spec:
+  ports:
+    - targetPort: 8080
+      port: 80
+      name: http
+  selector:
+    app: web
+metadata:
+  name: web
+kind: Service
+apiVersion: v1
+---
+data:
+  key: value
+kind: ConfigMap
+apiVersion: v1
+metadata:
+  name: config
+`
+    want := `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+spec:
+  template:
+    spec:
+      initContainers:
+        - name: migrate
+          image: migrate
+          command: ["sh", "-c", "migrate"]
+      containers:
+        - name: web
+          image: nginx
+          ports:
+            - containerPort: 80
+        - name: sidecar
+          image: busybox
+          env:
+            - name: B
+              value: "1"
+            - name: A
+              value: "2"
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: web
+spec:
+  selector:
+    app: web
+  ports:
+    - name: http
+      port: 80
+      targetPort: 8080
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: config
+data:
+  key: value
+`
+    out, err := Format([]byte(input), func(doc *yaml.Node) (*Rule, error) {
+        return LoadRule(K8sRulePath("../rules", doc))
+    })
+    if err != nil {
+        t.Fatalf("Format: %v", err)
+    }
+    if string(out) != want {
+        t.Errorf("got:\n%s\nwant:\n%s", out, want)
+    }
+}
@@ -0,0 +1,12 @@
+package sorter
+
+import "path/filepath"
+
+// kustomization.yaml 이면 kustomization.rule.yaml 경로와 true
+func KustomizationRulePath(dir, filename string) (string, bool) {
+    switch filepath.Base(filename) {
+    case "kustomization.yaml", "kustomization.yml", "Kustomization":
+        return filepath.Join(dir, "kustomization.rule.yaml"), true
+    }
+    return "", false
+}
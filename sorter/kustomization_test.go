@@ -0,0 +1,55 @@
+package sorter
+
+import (
+    "path/filepath"
+    "testing"
+)
+
+func TestFormatKustomization(t *testing.T) {
+    rule := loadRule(t, "../rules/kustomization.rule.yaml")
+    input := `patches:
+  - target:
+      name: web
+      kind: Deployment
+    path: ./patches/../replicas.yaml
+  - path: image.yaml
+resources:
+  - ./service.yaml
+  - github.com/org/repo//base?ref=v1
+  - deployment.yaml
+  - ../base/
+namespace: prod
+kind: Kustomization
+apiVersion: kustomize.config.k8s.io/v1beta1
+`
+    want := `apiVersion: kustomize.config.k8s.io/v1beta1
+kind: Kustomization
+namespace: prod
+resources:
+  - ../base
+  - deployment.yaml
+  - github.com/org/repo//base?ref=v1
+  - service.yaml
+patches:
+  - path: replicas.yaml
+    target:
+      kind: Deployment
+      name: web
+  - path: image.yaml
+`
+    if got := formatWith(t, rule, input); got != want {
+        t.Errorf("got:\n%s\nwant:\n%s", got, want)
+    }
+}
+
+func TestKustomizationRulePath(t *testing.T) {
+    for _, name := range []string{"kustomization.yaml", "overlays/prod/kustomization.yml", "Kustomization"} {
+        got, ok := KustomizationRulePath("rules", name)
+        if !ok || got != filepath.Join("rules", "kustomization.rule.yaml") {
+            t.Errorf("KustomizationRulePath(%q) = %q, %v", name, got, ok)
+        }
+    }
+    if _, ok := KustomizationRulePath("rules", "deployment.yaml"); ok {
+        t.Error("deployment.yaml should not select the kustomization rule")
+    }
+}
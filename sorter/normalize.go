@@ -0,0 +1,46 @@
+package sorter
+
+import (
+    "path"
+    "sort"
+    "strings"
+
+    "gopkg.in/yaml.v3"
+)
+
+// sort_list: 항목이 전부 scalar 인 리스트만 값 순서로 정렬한다. mapping 항목이 섞여 있으면 그대로 둔다.
+func sortScalarList(node *yaml.Node) {
+    if node.Kind != yaml.SequenceNode {
+        return
+    }
+    for _, item := range node.Content {
+        if item.Kind != yaml.ScalarNode {
+            return
+        }
+    }
+    sort.SliceStable(node.Content, func(i, j int) bool {
+        return node.Content[i].Value < node.Content[j].Value
+    })
+}
+
+// clean_path: scalar 이거나 scalar 리스트인 상대 경로를 path.Clean 으로 정리한다 ("./a/../b.yaml" -> "b.yaml")
+func cleanPaths(node *yaml.Node) {
+    switch node.Kind {
+    case yaml.ScalarNode:
+        node.Value = cleanPath(node.Value)
+    case yaml.SequenceNode:
+        for _, item := range node.Content {
+            if item.Kind == yaml.ScalarNode {
+                item.Value = cleanPath(item.Value)
+            }
+        }
+    }
+}
+
+func cleanPath(p string) string {
+    // 원격 리소스(https://..., git@..., github.com/org/repo//dir?ref=) 는 건드리지 않는다
+    if p == "" || strings.Contains(p, "//") || strings.Contains(p, "?") || strings.HasPrefix(p, "git@") {
+        return p
+    }
+    return path.Clean(p)
+}
@@ -0,0 +1,44 @@
+package sorter
+
+import (
+    "os"
+    "strings"
+    "testing"
+)
+
+// $ref 값과 /pets/{petId} 같은 path 키는 따옴표까지 그대로여야 한다
+func TestFormatOpenAPIKeepsRefsAndPaths(t *testing.T) {
+    rule := loadRule(t, "../rules/openapi.rule.yaml")
+    data, err := os.ReadFile("../test/openapi.yaml")
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    // 최상위 순서를 섞어서 정렬이 실제로 일어나게 한다
+    input := string(data)
+    i := strings.Index(input, "paths:\n")
+    j := strings.Index(input, "components:\n")
+    input = input[i:j] + input[j:] + input[:i]
+
+    // info 의 version 만 rule 순서대로 뒤로 가고 나머지는 원본과 같아야 한다
+    want := strings.Replace(string(data),
+        "  version: 1.0.0\n  title: Swagger Petstore\n  license:\n    name: MIT\n",
+        "  title: Swagger Petstore\n  license:\n    name: MIT\n  version: 1.0.0\n", 1)
+
+    got := formatWith(t, rule, input)
+    if got != want {
+        t.Errorf("got:\n%s\nwant:\n%s", got, want)
+    }
+
+    for _, line := range []string{
+        `                $ref: "#/components/schemas/Pets"`,
+        `              $ref: '#/components/schemas/Pet'`,
+        `        $ref: "#/components/schemas/Pet"`,
+        `  /pets/{petId}:`,
+        `        '200':`,
+    } {
+        if !strings.Contains(got, line+"\n") {
+            t.Errorf("output is missing %q", line)
+        }
+    }
+}
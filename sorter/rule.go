@@ -0,0 +1,100 @@
+package sorter
+
+import (
+    "fmt"
+    "io/fs"
+    "os"
+
+    "gopkg.in/yaml.v3"
+)
+
+// rule 파일에서 "이 mapping 의 모든 항목"을 뜻하는 키
+const wildcardKey = "*"
+
+// non_sort, sort_list, clean_path 를 뺀 rule 파일 내용은 Order 에,
+// 그 세 섹션에 나열된 키 이름은 각각 NonSort, SortList, CleanPath 에 담는다
+type Rule struct {
+    Order     *yaml.Node
+    NonSort   map[string]bool
+    SortList  map[string]bool
+    CleanPath map[string]bool
+}
+
+func LoadRule(path string) (*Rule, error) {
+    data, err := os.ReadFile(path)
+    if err != nil {
+        return nil, err
+    }
+    rule, err := ParseRule(data)
+    if err != nil {
+        return nil, fmt.Errorf("%s: %w", path, err)
+    }
+    return rule, nil
+}
+
+// 내장 rule(rules.FS) 처럼 fs.FS 안에 있는 rule 파일을 읽는다
+func LoadRuleFS(fsys fs.FS, name string) (*Rule, error) {
+    data, err := fs.ReadFile(fsys, name)
+    if err != nil {
+        return nil, err
+    }
+    rule, err := ParseRule(data)
+    if err != nil {
+        return nil, fmt.Errorf("%s: %w", name, err)
+    }
+    return rule, nil
+}
+
+func ParseRule(data []byte) (*Rule, error) {
+    var doc yaml.Node
+    if err := yaml.Unmarshal(data, &doc); err != nil {
+        return nil, err
+    }
+    if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+        return nil, fmt.Errorf("rule must be a mapping")
+    }
+
+    root := doc.Content[0]
+    rule := &Rule{
+        Order:     &yaml.Node{Kind: yaml.MappingNode},
+        NonSort:   make(map[string]bool),
+        SortList:  make(map[string]bool),
+        CleanPath: make(map[string]bool),
+    }
+    for i := 0; i+1 < len(root.Content); i += 2 {
+        key, value := root.Content[i], root.Content[i+1]
+        switch key.Value {
+        case "non_sort":
+            addKeys(rule.NonSort, value)
+        case "sort_list":
+            addKeys(rule.SortList, value)
+        case "clean_path":
+            addKeys(rule.CleanPath, value)
+        default:
+            rule.Order.Content = append(rule.Order.Content, key, value)
+        }
+    }
+    return rule, nil
+}
+
+func addKeys(set map[string]bool, section *yaml.Node) {
+    if section.Kind != yaml.MappingNode {
+        return
+    }
+    for i := 0; i < len(section.Content); i += 2 {
+        set[section.Content[i].Value] = true
+    }
+}
+
+// mapping 노드에서 key 에 해당하는 값을 찾는다. 없으면 nil
+func MappingValue(node *yaml.Node, key string) *yaml.Node {
+    if node == nil || node.Kind != yaml.MappingNode {
+        return nil
+    }
+    for i := 0; i+1 < len(node.Content); i += 2 {
+        if node.Content[i].Value == key {
+            return node.Content[i+1]
+        }
+    }
+    return nil
+}
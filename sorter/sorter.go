@@ -0,0 +1,228 @@
+package sorter
+
+import (
+    "bytes"
+    "errors"
+    "io"
+
+    "gopkg.in/yaml.v3"
+)
+
+// data 의 문서마다 ruleFor 가 고른 rule 로 키를 정렬한다.
+// 값은 노드 그대로 옮기기만 하므로 scalar 표기, tag, 주석은 바뀌지 않는다.
+func Format(data []byte, ruleFor func(doc *yaml.Node) (*Rule, error)) ([]byte, error) {
+    decoder := yaml.NewDecoder(bytes.NewReader(data))
+
+    var out bytes.Buffer
+    encoder := yaml.NewEncoder(&out)
+    encoder.SetIndent(2)
+
+    count := 0
+    for {
+        var doc yaml.Node
+        err := decoder.Decode(&doc)
+        if errors.Is(err, io.EOF) {
+            break
+        }
+        if err != nil {
+            return nil, err
+        }
+
+        rule, err := ruleFor(&doc)
+        if err != nil {
+            return nil, err
+        }
+        Sort(&doc, rule)
+        clearMergeTags(&doc)
+
+        if err := encoder.Encode(&doc); err != nil {
+            return nil, err
+        }
+        count++
+    }
+    // 빈 파일이나 주석만 있는 파일은 그대로 돌려준다
+    if count == 0 {
+        return data, nil
+    }
+    if err := encoder.Close(); err != nil {
+        return nil, err
+    }
+    return out.Bytes(), nil
+}
+
+// node 를 rule 순서에 맞게 제자리에서 정렬한다
+func Sort(node *yaml.Node, rule *Rule) {
+    if rule == nil {
+        return
+    }
+    sortNode(node, rule.Order, rule)
+}
+
+func sortNode(node *yaml.Node, order *yaml.Node, rule *Rule) {
+    if order == nil || order.Kind != yaml.MappingNode {
+        return
+    }
+
+    switch node.Kind {
+    case yaml.DocumentNode:
+        for _, child := range node.Content {
+            sortNode(child, order, rule)
+        }
+    case yaml.SequenceNode:
+        // 리스트 순서는 그대로 두고, 항목이 mapping 이면 같은 rule 로 정렬
+        for _, item := range node.Content {
+            sortNode(item, order, rule)
+        }
+    case yaml.MappingNode:
+        sortMapping(node, order)
+
+        wildcard := MappingValue(order, wildcardKey)
+        for i := 0; i+1 < len(node.Content); i += 2 {
+            key, value := node.Content[i].Value, node.Content[i+1]
+            if rule.CleanPath[key] {
+                cleanPaths(value)
+            }
+            if rule.SortList[key] {
+                sortScalarList(value)
+            }
+
+            if sub := MappingValue(order, key); sub != nil {
+                if !rule.NonSort[key] {
+                    sortNode(value, sub, rule)
+                }
+            } else if wildcard != nil {
+                // services, jobs 처럼 이름이 정해지지 않은 항목들
+                sortNode(value, wildcard, rule)
+            }
+        }
+    }
+}
+
+// 병합 키 "<<: *anchor"
+func isMergeKey(key *yaml.Node) bool {
+    return key.Kind == yaml.ScalarNode && key.Value == "<<" && (key.Tag == "!!merge" || key.Tag == "")
+}
+
+// 디코딩된 "<<" 키에는 !!merge tag 가 붙어 있어서 그대로 쓰면 "!!merge <<:" 로 나온다
+func clearMergeTags(node *yaml.Node) {
+    if node.Kind == yaml.AliasNode {
+        return
+    }
+    if node.Kind == yaml.MappingNode {
+        for i := 0; i+1 < len(node.Content); i += 2 {
+            if isMergeKey(node.Content[i]) {
+                node.Content[i].Tag = ""
+            }
+        }
+    }
+    for _, child := range node.Content {
+        clearMergeTags(child)
+    }
+}
+
+// rule 에 나온 순서대로 키/값 쌍을 옮긴다. rule 에 없는 키는 원래 순서대로 "*" 자리에,
+// "*" 가 없으면 뒤에 붙인다. "<<" 병합 키는 항상 맨 위에 둔다.
+func sortMapping(node *yaml.Node, order *yaml.Node) {
+    sorted := make([]*yaml.Node, 0, len(node.Content))
+    used := make([]bool, len(node.Content)/2)
+
+    for j := 0; j+1 < len(node.Content); j += 2 {
+        if isMergeKey(node.Content[j]) {
+            sorted = append(sorted, node.Content[j], node.Content[j+1])
+            used[j/2] = true
+        }
+    }
+
+    // rule 에 "*" 가 있으면 rule 에 없는 키는 그 자리에 넣는다
+    slot := -1
+    for i := 0; i+1 < len(order.Content); i += 2 {
+        ruleKey := order.Content[i].Value
+        if ruleKey == wildcardKey {
+            slot = len(sorted)
+            continue
+        }
+        for j := 0; j+1 < len(node.Content); j += 2 {
+            if !used[j/2] && node.Content[j].Value == ruleKey {
+                sorted = append(sorted, node.Content[j], node.Content[j+1])
+                used[j/2] = true
+            }
+        }
+    }
+
+    var unknown []*yaml.Node
+    for j := 0; j+1 < len(node.Content); j += 2 {
+        if !used[j/2] {
+            unknown = append(unknown, node.Content[j], node.Content[j+1])
+        }
+    }
+    if slot < 0 {
+        sorted = append(sorted, unknown...)
+    } else {
+        sorted = append(sorted[:slot], append(unknown, sorted[slot:]...)...)
+    }
+    node.Content = keepAnchorsFirst(sorted)
+}
+
+// 앵커를 정의한 쌍이 그 앵커를 쓰는 쌍보다 뒤로 가면 "unknown anchor" 로 다시 읽을 수 없게 된다.
+// compose 의 "x-common: &common" + "<<: *common" 처럼 rule 에 없는 키가 뒤로 밀린 경우,
+// 앵커를 정의한 쌍을 쓰는 쌍 바로 앞으로 옮긴다.
+func keepAnchorsFirst(content []*yaml.Node) []*yaml.Node {
+    n := len(content) / 2
+    defines := make([]map[*yaml.Node]bool, n)
+    uses := make([]map[*yaml.Node]bool, n)
+    for i := 0; i < n; i++ {
+        defines[i] = make(map[*yaml.Node]bool)
+        uses[i] = make(map[*yaml.Node]bool)
+        collectAnchors(content[2*i], defines[i], uses[i])
+        collectAnchors(content[2*i+1], defines[i], uses[i])
+    }
+
+    // 원래 문서에서는 앵커가 항상 먼저 나오므로 의존 관계에 순환은 없다
+    pairs := make([]int, n)
+    for i := range pairs {
+        pairs[i] = i
+    }
+    for i := 0; i < len(pairs); {
+        moved := false
+        for j := i + 1; j < len(pairs); j++ {
+            if refersTo(uses[pairs[i]], defines[pairs[j]]) {
+                p := pairs[j]
+                copy(pairs[i+1:j+1], pairs[i:j])
+                pairs[i] = p
+                moved = true
+                break
+            }
+        }
+        if !moved {
+            i++
+        }
+    }
+
+    result := make([]*yaml.Node, 0, len(content))
+    for _, p := range pairs {
+        result = append(result, content[2*p], content[2*p+1])
+    }
+    return result
+}
+
+func collectAnchors(node *yaml.Node, defines, uses map[*yaml.Node]bool) {
+    if node.Kind == yaml.AliasNode {
+        uses[node.Alias] = true
+        return
+    }
+    if node.Anchor != "" {
+        defines[node] = true
+    }
+    for _, child := range node.Content {
+        collectAnchors(child, defines, uses)
+    }
+}
+
+func refersTo(uses, defines map[*yaml.Node]bool) bool {
+    for anchor := range uses {
+        if defines[anchor] {
+            return true
+        }
+    }
+    return false
+}
@@ -0,0 +1,185 @@
+package sorter
+
+import (
+    "testing"
+
+    "gopkg.in/yaml.v3"
+)
+
+func formatWith(t *testing.T, rule *Rule, input string) string {
+    t.Helper()
+    out, err := Format([]byte(input), func(doc *yaml.Node) (*Rule, error) {
+        return rule, nil
+    })
+    if err != nil {
+        t.Fatalf("Format: %v", err)
+    }
+    return string(out)
+}
+
+func loadRule(t *testing.T, path string) *Rule {
+    t.Helper()
+    rule, err := LoadRule(path)
+    if err != nil {
+        t.Fatalf("LoadRule: %v", err)
+    }
+    return rule
+}
+
+func parseRule(t *testing.T, data string) *Rule {
+    t.Helper()
+    rule, err := ParseRule([]byte(data))
+    if err != nil {
+        t.Fatalf("ParseRule: %v", err)
+    }
+    return rule
+}
+
+func TestFormatKeepsScalarText(t *testing.T) {
+    rule := loadRule(t, "../rules/docker-compose-spec.rule.yaml")
+    input := `services:
+  app:
+    restart: no
+    user: 0755
+    environment:
+      DEBUG: on
+    image: app:latest
+`
+    want := `services:
+  app:
+    image: app:latest
+    environment:
+      DEBUG: on
+    user: 0755
+    restart: no
+`
+    if got := formatWith(t, rule, input); got != want {
+        t.Errorf("got:\n%s\nwant:\n%s", got, want)
+    }
+}
+
+func TestFormatKeepsUnknownKeysInOrder(t *testing.T) {
+    rule := parseRule(t, `
+b:
+a:
+non_sort:
+  x:
+`)
+    input := "z: 1\na: 2\ny: 3\nb: 4\n"
+    want := "b: 4\na: 2\nz: 1\ny: 3\n"
+    if got := formatWith(t, rule, input); got != want {
+        t.Errorf("got:\n%s\nwant:\n%s", got, want)
+    }
+}
+
+func TestFormatUnknownKeySlot(t *testing.T) {
+    rule := parseRule(t, `
+name:
+"*":
+when:
+`)
+    input := "when: x\nfoo: 1\nname: n\nbar: 2\n"
+    want := "name: n\nfoo: 1\nbar: 2\nwhen: x\n"
+    if got := formatWith(t, rule, input); got != want {
+        t.Errorf("got:\n%s\nwant:\n%s", got, want)
+    }
+}
+
+func TestFormatNonSort(t *testing.T) {
+    rule := parseRule(t, `
+outer:
+  b:
+  a:
+non_sort:
+  outer:
+`)
+    input := "outer:\n  a: 1\n  b: 2\n"
+    if got := formatWith(t, rule, input); got != input {
+        t.Errorf("non_sort key was reordered:\n%s", got)
+    }
+}
+
+func TestFormatWildcard(t *testing.T) {
+    rule := parseRule(t, `
+services:
+  "*":
+    image:
+    ports:
+`)
+    input := `services:
+  web:
+    ports: [80]
+    image: nginx
+  db:
+    ports: [5432]
+    image: postgres
+`
+    want := `services:
+  web:
+    image: nginx
+    ports: [80]
+  db:
+    image: postgres
+    ports: [5432]
+`
+    if got := formatWith(t, rule, input); got != want {
+        t.Errorf("got:\n%s\nwant:\n%s", got, want)
+    }
+}
+
+func TestParseRuleRejectsNonMapping(t *testing.T) {
+    if _, err := ParseRule([]byte("- a\n- b\n")); err == nil {
+        t.Error("expected error for sequence rule")
+    }
+}
+
+func TestMappingValue(t *testing.T) {
+    var doc yaml.Node
+    if err := yaml.Unmarshal([]byte("a: 1\nb: 2\n"), &doc); err != nil {
+        t.Fatal(err)
+    }
+    if v := MappingValue(doc.Content[0], "b"); v == nil || v.Value != "2" {
+        t.Errorf("MappingValue(b) = %v", v)
+    }
+    if v := MappingValue(doc.Content[0], "c"); v != nil {
+        t.Errorf("MappingValue(c) = %v, want nil", v)
+    }
+}
+
+func TestFormatMergeKey(t *testing.T) {
+    rule := parseRule(t, `
+base:
+services:
+  "*":
+    image:
+    restart:
+`)
+    input := `base: &base
+  restart: always
+services:
+  web:
+    restart: "no"
+    image: nginx
+    <<: *base
+`
+    want := `base: &base
+  restart: always
+services:
+  web:
+    <<: *base
+    image: nginx
+    restart: "no"
+`
+    if got := formatWith(t, rule, input); got != want {
+        t.Errorf("got:\n%s\nwant:\n%s", got, want)
+    }
+}
+
+func TestFormatWithoutDocuments(t *testing.T) {
+    rule := parseRule(t, "a:\n")
+    for _, input := range []string{"", "# only comment\n", "\n\n"} {
+        if got := formatWith(t, rule, input); got != input {
+            t.Errorf("Format(%q) = %q, want input unchanged", input, got)
+        }
+    }
+}